@@ -0,0 +1,158 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parse
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"robpike.io/ivy/config"
+	"robpike.io/ivy/value"
+)
+
+const defaultBinaryFile = "save.ivybin"
+
+// binaryMagic identifies an ivy binary workspace file; binaryVersion lets
+// us change the envelope layout later without breaking old files outright.
+const (
+	binaryMagic   = "ivyb"
+	binaryVersion = 1
+)
+
+// binaryHeader is the envelope written ahead of the gob-encoded workspace.
+// It records the configuration the values were saved under, for reference
+// and for future format checks. )getbin does not apply it to the loading
+// session: BigFloat's own GobEncode already carries each value's exact
+// precision, so nothing here is needed to restore values correctly, and
+// silently changing the caller's )ibase/)obase/)prec/)origin out from
+// under them would be a surprising side effect that text-mode )get doesn't
+// have either.
+type binaryHeader struct {
+	Magic   string
+	Version int
+	IBase   int
+	OBase   int
+	Origin  int
+	Prec    uint
+}
+
+// binaryWorkspace is the gob-encoded payload. Vars round-trip through
+// BigInt and BigFloat's GobEncode/GobDecode (inherited from math/big),
+// which preserves BigFloat mantissas bit-for-bit instead of the lossy
+// reparse-at-current-prec that the text )save format does.
+//
+// User-defined operators have no GobEncode of their own, so Ops carries
+// them as their ordinary ivy source text (the same text "op f x = ..."
+// a user would type, produced by UnaryFn/BinaryFn's own String method).
+// loadBinary replays that text through runSource, the same evaluator
+// )get uses, rather than inventing a second op format.
+type binaryWorkspace struct {
+	Vars map[string]value.Value
+	Ops  []string
+}
+
+func init() {
+	gob.Register(value.BigInt{})
+	gob.Register(value.BigFloat{})
+	gob.Register(value.BigRat{})
+	gob.Register(value.Int(0))
+	gob.Register(value.Char(0))
+	gob.Register(value.Complex{})
+	gob.Register(value.Vector{})
+	gob.Register(value.Matrix{})
+}
+
+// saveBinary writes the context's global variables and user-defined
+// operators to file in ivy's binary workspace format. Variables preserve
+// exact BigFloat mantissas and avoid the O(n·prec) cost of formatting and
+// reparsing large values that the text )save format pays; operators have
+// no such representation to preserve, so they ride along as plain ivy
+// source text (see binaryWorkspace).
+func saveBinary(context value.Context, file string, conf *config.Config) {
+	ibase, obase := conf.Base()
+	header := binaryHeader{
+		Magic:   binaryMagic,
+		Version: binaryVersion,
+		IBase:   ibase,
+		OBase:   obase,
+		Origin:  conf.Origin(),
+		Prec:    conf.FloatPrec(),
+	}
+
+	vars := make(map[string]value.Value)
+	for name, v := range context.Globals() {
+		vars[name] = v
+	}
+
+	var ops []string
+	for _, fn := range context.UnaryFn {
+		if fn != nil {
+			ops = append(ops, fmt.Sprint(fn))
+		}
+	}
+	for _, fn := range context.BinaryFn {
+		if fn != nil {
+			ops = append(ops, fmt.Sprint(fn))
+		}
+	}
+	sort.Strings(ops)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(header); err != nil {
+		value.Errorf("savebin: %s", err)
+	}
+	if err := gob.NewEncoder(&buf).Encode(binaryWorkspace{Vars: vars, Ops: ops}); err != nil {
+		value.Errorf("savebin: %s", err)
+	}
+
+	if err := os.WriteFile(file, buf.Bytes(), 0664); err != nil {
+		value.Errorf("savebin: %s", err)
+	}
+}
+
+// loadBinary reads a file written by saveBinary, restoring each variable
+// at the precision it was saved with and replaying each saved operator
+// definition as ivy source, installing both into the context. The
+// session's current )ibase, )obase, )prec, and )origin are left alone,
+// the same as text-mode )get; see binaryHeader for why.
+func loadBinary(p *Parser, file string) {
+	conf := p.config
+	data, err := os.ReadFile(file)
+	if err != nil {
+		value.Errorf("getbin: %s", err)
+	}
+
+	r := bytes.NewReader(data)
+	dec := gob.NewDecoder(r)
+
+	var header binaryHeader
+	if err := dec.Decode(&header); err != nil {
+		value.Errorf("getbin: %s", err)
+	}
+	if header.Magic != binaryMagic {
+		value.Errorf("getbin: %q is not an ivy binary workspace file", file)
+	}
+	if header.Version > binaryVersion {
+		value.Errorf("getbin: %q was saved by a newer version of ivy", file)
+	}
+
+	var workspace binaryWorkspace
+	if err := dec.Decode(&workspace); err != nil {
+		value.Errorf("getbin: %s", err)
+	}
+
+	for name, v := range workspace.Vars {
+		p.context.SetGlobal(name, v)
+	}
+	for _, src := range workspace.Ops {
+		p.runSource(p.context, file, strings.NewReader(src))
+	}
+	fmt.Fprintf(conf.Output(), "loaded %d variable(s) and %d operator(s) from %s\n",
+		len(workspace.Vars), len(workspace.Ops), file)
+}
@@ -0,0 +1,86 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parse
+
+import (
+	"bufio"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"robpike.io/ivy/config"
+	"robpike.io/ivy/exec"
+	"robpike.io/ivy/scan"
+	"robpike.io/ivy/value"
+)
+
+// run evaluates src as a sequence of ivy input lines against context,
+// the same way the interactive REPL and )get do.
+func run(t *testing.T, conf *config.Config, context value.Context, src string) {
+	t.Helper()
+	scanner := scan.New(conf, context, t.Name(), bufio.NewReader(strings.NewReader(src)))
+	parser := NewParser(conf, t.Name(), scanner, context)
+	for {
+		vals, ok := parser.Line()
+		for _, v := range vals {
+			v.Eval(context)
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+func TestSaveBinaryLoadBinaryRoundTrip(t *testing.T) {
+	var conf config.Config
+	context := exec.NewContext(&conf)
+
+	run(t, &conf, context, "x = 3.25\n")
+	run(t, &conf, context, "op double n = n + n\n")
+
+	file := filepath.Join(t.TempDir(), "workspace.ivybin")
+	saveBinary(context, file, &conf)
+
+	// Load into a fresh context so we're checking what was actually
+	// written, not what's still lying around in the original.
+	loadContext := exec.NewContext(&conf)
+	scanner := scan.New(&conf, loadContext, t.Name(), bufio.NewReader(strings.NewReader("")))
+	p := NewParser(&conf, t.Name(), scanner, loadContext)
+	loadBinary(p, file)
+
+	got, ok := loadContext.Globals()["x"]
+	if !ok {
+		t.Fatal("x was not restored by loadBinary")
+	}
+	if got, want := got.(value.BigFloat).Float.String(), "3.25"; got != want {
+		t.Errorf("restored x = %s, want %s", got, want)
+	}
+
+	if loadContext.BinaryFn["double"] == nil && loadContext.UnaryFn["double"] == nil {
+		t.Error("op double was not restored by loadBinary")
+	}
+}
+
+func TestSaveBinaryPreservesBigFloatPrecision(t *testing.T) {
+	var conf config.Config
+	conf.SetFloatPrec(500)
+	context := exec.NewContext(&conf)
+	run(t, &conf, context, "x = sqrt 2\n")
+
+	file := filepath.Join(t.TempDir(), "workspace.ivybin")
+	saveBinary(context, file, &conf)
+
+	loadContext := exec.NewContext(&conf)
+	scanner := scan.New(&conf, loadContext, t.Name(), bufio.NewReader(strings.NewReader("")))
+	p := NewParser(&conf, t.Name(), scanner, loadContext)
+	loadBinary(p, file)
+
+	saved := context.Globals()["x"].(value.BigFloat).Float
+	restored := loadContext.Globals()["x"].(value.BigFloat).Float
+	if saved.Prec() != restored.Prec() || saved.Cmp(restored) != 0 {
+		t.Errorf("restored x = %v (prec %d), want %v (prec %d) — BigFloat mantissa/precision should round-trip exactly through gob",
+			restored, restored.Prec(), saved, saved.Prec())
+	}
+}
@@ -9,6 +9,7 @@ package parse // import "robpike.io/ivy/parse"
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 
 	"robpike.io/ivy/config"
@@ -138,13 +139,23 @@ Switch:
 			p.Printf("%q\n", p.config.Format())
 			break Switch
 		}
-		p.config.SetFormat(p.getString())
+		format := p.getString()
+		if !value.ValidFormat(format) {
+			p.errorf("%q: invalid format", format)
+		}
+		p.config.SetFormat(format)
 	case "get":
 		if p.peek().Type == scan.Newline {
 			p.runFromFile(p.context, defaultFile)
 		} else {
 			p.runFromFile(p.context, p.getString())
 		}
+	case "getbin":
+		if p.peek().Type == scan.Newline {
+			loadBinary(p, defaultBinaryFile)
+		} else {
+			loadBinary(p, p.getString())
+		}
 	case "maxbits":
 		if p.peek().Type == scan.Newline {
 			p.Printf("%d\n", p.config.MaxBits())
@@ -208,6 +219,12 @@ Switch:
 		} else {
 			save(p.context, p.getString(), p.config)
 		}
+	case "savebin":
+		if p.peek().Type == scan.Newline {
+			saveBinary(p.context, defaultBinaryFile, p.config)
+		} else {
+			saveBinary(p.context, p.getString(), p.config)
+		}
 	case "seed":
 		if p.peek().Type == scan.Newline {
 			p.Println(p.config.Origin())
@@ -233,8 +250,23 @@ func (p *Parser) runFromFile(context value.Context, name string) {
 	if runDepth > 10 {
 		p.errorf("get %q nested too deep", name)
 	}
+	defer func() { runDepth-- }()
+	fd, err := os.Open(name)
+	if err != nil {
+		p.errorf("%s", err)
+	}
+	defer fd.Close()
+	p.runSource(context, name, fd)
+}
+
+// runSource evaluates ivy source read from r line by line, printing each
+// result through value.Sprint with the configured )format. name is
+// used only for error messages. This is factored out of runFromFile so
+// )getbin can replay the user-defined operators saved alongside the
+// binary variable payload (see loadBinary) through the same path )get
+// already uses, rather than a second copy of it.
+func (p *Parser) runSource(context value.Context, name string, r io.Reader) {
 	defer func() {
-		runDepth--
 		err := recover()
 		if err == nil {
 			return
@@ -245,21 +277,17 @@ func (p *Parser) runFromFile(context value.Context, name string) {
 		}
 		panic(err)
 	}()
-	fd, err := os.Open(name)
-	if err != nil {
-		p.errorf("%s", err)
-	}
-	scanner := scan.New(p.config, context, name, bufio.NewReader(fd))
+	scanner := scan.New(p.config, context, name, bufio.NewReader(r))
 	parser := NewParser(p.config, name, scanner, p.context)
 	out := p.config.Output()
 	for {
-		value, ok := parser.Line()
-		for _, val := range value {
+		vals, ok := parser.Line()
+		for _, val := range vals {
 			val = val.Eval(p.context)
 			if val == nil {
 				continue
 			}
-			fmt.Fprintf(out, "%v\n", val)
+			fmt.Fprintln(out, value.Sprint(val, p.config.Format()))
 		}
 		if !ok {
 			return
@@ -0,0 +1,59 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestFloatAtan(t *testing.T) {
+	for _, x := range []float64{
+		0, 0.05, 0.1989123673, 0.5, 1, 1.5, 2, 10, 1e6, -3, -1e6,
+	} {
+		got, _ := floatAtan(big.NewFloat(x).SetPrec(200)).Float64()
+		want := math.Atan(x)
+		if !nearlyEqual(got, want) {
+			t.Errorf("floatAtan(%v) = %v, want %v", x, got, want)
+		}
+	}
+}
+
+func TestFloatAtanAboveHalfAngleThreshold(t *testing.T) {
+	// Values above tan(pi/16) but at most 1 go straight into the half-angle
+	// reduction loop; values above 1 are first folded to 1/x, which runs
+	// the loop for 3 but lands below the threshold for 100 and 1e9.
+	for _, x := range []float64{0.5, 3, 100, 1e9} {
+		got, _ := floatAtan(big.NewFloat(x).SetPrec(200)).Float64()
+		want := math.Atan(x)
+		if !nearlyEqual(got, want) {
+			t.Errorf("floatAtan(%v) = %v, want %v", x, got, want)
+		}
+	}
+}
+
+func TestFloatAsinAcosUseReducedAtan(t *testing.T) {
+	// asin/acos are defined in terms of floatAtan, so a regression in the
+	// half-angle reduction would show up here too.
+	for _, x := range []float64{-1, -0.5, 0, 0.5, 1} {
+		gotAsin, _ := floatAsin(big.NewFloat(x).SetPrec(200)).Float64()
+		if wantAsin := math.Asin(x); !nearlyEqual(gotAsin, wantAsin) {
+			t.Errorf("floatAsin(%v) = %v, want %v", x, gotAsin, wantAsin)
+		}
+		gotAcos, _ := floatAcos(big.NewFloat(x).SetPrec(200)).Float64()
+		if wantAcos := math.Acos(x); !nearlyEqual(gotAcos, wantAcos) {
+			t.Errorf("floatAcos(%v) = %v, want %v", x, gotAcos, wantAcos)
+		}
+	}
+}
+
+func TestUnaryFnRegistersAtanFamily(t *testing.T) {
+	for _, name := range []string{"asin", "acos", "atan"} {
+		if unaryFn[name] == nil {
+			t.Errorf("unaryFn[%q] is not registered", name)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import "math/big"
+
+// atan2 implements the dyadic operator "y atan2 x", the two-argument
+// arctangent that returns the angle of the point (x, y), resolving the
+// quadrant ambiguity that the unary atan cannot.
+func atan2(u, v Value) Value {
+	return evalFloatFloatFunc(u, v, floatAtan2)
+}
+
+func evalFloatFloatFunc(u, v Value, fn func(y, x *big.Float) *big.Float) Value {
+	y := floatSelf(nil, u).(BigFloat).Float
+	x := floatSelf(nil, v).(BigFloat).Float
+	return BigFloat{fn(y, x)}.shrink()
+}
+
+// floatAtan2 computes atan2(y, x), the angle of the point (x, y), reusing
+// floatAtan and handling the quadrant, sign, and zero cases the way
+// math/atan2.go does. big.Float's Sign is 0 for both +0 and -0, so the
+// zero cases below test Signbit explicitly wherever +0 and -0 must be
+// told apart:
+//
+//	x > 0                     atan(y/x)
+//	x < 0, y ≥ 0              atan(y/x) + π
+//	x < 0, y < 0              atan(y/x) - π
+//	x = ±0, y > 0             π/2
+//	x = ±0, y < 0             -π/2
+//	y = 0, x ≥ 0 (not -0)     copy of y (±0)
+//	y = 0, x < 0 or x = -0    ±π, sign from y
+func floatAtan2(y, x *big.Float) *big.Float {
+	switch {
+	case y.Sign() == 0:
+		if x.Sign() > 0 || (x.Sign() == 0 && !x.Signbit()) {
+			return newF().Set(y)
+		}
+		z := newF().Set(floatPi)
+		if y.Signbit() {
+			z.Neg(z)
+		}
+		return z
+	case x.Sign() == 0:
+		// x = ±0, y ≠ 0: the result only depends on y's sign.
+		z := newF().Set(floatPi)
+		z.Quo(z, floatTwo)
+		if y.Sign() < 0 {
+			z.Neg(z)
+		}
+		return z
+	case x.Sign() < 0:
+		z := newF().Quo(y, x)
+		z = floatAtan(z)
+		if y.Sign() > 0 {
+			return z.Add(z, floatPi)
+		}
+		return z.Sub(z, floatPi)
+	default: // x > 0
+		z := newF().Quo(y, x)
+		return floatAtan(z)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestFloatAtan2(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		y, x float64
+	}{
+		{"x>0, y>0", 1, 2},
+		{"x>0, y<0", -1, 2},
+		{"x<0, y>0", 1, -2},
+		{"x<0, y<0", -1, -2},
+		{"x<0, y=0", 0, -2},
+		{"x>0, y=0", 0, 2},
+		{"x=0, y>0", 3, 0},
+		{"x=0, y<0", -3, 0},
+	} {
+		y := big.NewFloat(test.y).SetPrec(200)
+		x := big.NewFloat(test.x).SetPrec(200)
+		got, _ := floatAtan2(y, x).Float64()
+		want := math.Atan2(test.y, test.x)
+		if !nearlyEqual(got, want) {
+			t.Errorf("%s: floatAtan2(%v, %v) = %v, want %v", test.name, test.y, test.x, got, want)
+		}
+	}
+}
+
+func TestFloatAtan2SignedZero(t *testing.T) {
+	posZero := big.NewFloat(0).SetPrec(200)
+	negZero := new(big.Float).SetPrec(200).Neg(posZero)
+	one := big.NewFloat(1).SetPrec(200)
+	negOne := big.NewFloat(-1).SetPrec(200)
+
+	for _, test := range []struct {
+		name    string
+		y, x    *big.Float
+		wantNeg bool // whether the result is signed-negative (matters for ±0 results)
+	}{
+		{"atan2(+0, +1) = +0", posZero, one, false},
+		{"atan2(-0, +1) = -0", negZero, one, true},
+		{"atan2(+0, -1) = +pi", posZero, negOne, false},
+		{"atan2(-0, -1) = -pi", negZero, negOne, true},
+	} {
+		z := floatAtan2(test.y, test.x)
+		if z.Signbit() != test.wantNeg {
+			t.Errorf("%s: got signbit %v, want %v (z = %v)", test.name, z.Signbit(), test.wantNeg, z)
+		}
+	}
+}
+
+func TestBinaryFnRegistersAtan2(t *testing.T) {
+	if binaryFn["atan2"] == nil {
+		t.Error(`binaryFn["atan2"] is not registered`)
+	}
+}
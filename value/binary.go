@@ -0,0 +1,13 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+// binaryFn maps the name ivy's parser recognizes for a built-in binary
+// operator to the Go function that implements it, the binary counterpart
+// of unaryFn. Without an entry here, "y atan2 x" has no way to reach
+// floatAtan2 from ivy syntax.
+var binaryFn = map[string]func(Value, Value) Value{
+	"atan2": atan2,
+}
@@ -0,0 +1,182 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// floatFormatVerbs are the big.Float.Text verbs we accept in a )format
+// spec, mirroring math/big's own ftoa.go: 'e' and 'E' for scientific
+// notation, 'f' for fixed-point, 'g' and 'G' for the shorter of the two,
+// and 'b' and 'p' for binary and power-of-two exponent forms.
+const floatFormatVerbs = "eEfgGbp"
+
+// parseFloatFormat parses a compact float format spec such as "%.20g",
+// "e30", or "g-1" into the verb and precision that big.Float.Text expects.
+// A leading '%' is optional and ignored, matching the printf-style specs
+// ivy users are already used to from )format. A precision of -1 requests
+// the shortest decimal that round-trips exactly, same as big.Float.Text.
+// ok is false if spec isn't one of these compact float specs, in which
+// case the caller should fall back to treating it as a plain printf verb.
+//
+// Both verb-first ("e30", the request's own example) and verb-last,
+// printf-style ("%.20g") specs are accepted; the verb can appear at
+// either end, so whichever end holds one of floatFormatVerbs wins.
+func parseFloatFormat(spec string) (verb byte, prec int, ok bool) {
+	spec = strings.TrimPrefix(spec, "%")
+	if spec == "" {
+		return 0, 0, false
+	}
+
+	if strings.ContainsRune(floatFormatVerbs, rune(spec[0])) {
+		prec, ok = parseFloatPrec(spec[1:])
+		if !ok {
+			return 0, 0, false
+		}
+		return spec[0], prec, true
+	}
+
+	// Verb-last: an optional leading '.', then digits, then the verb.
+	verb = spec[len(spec)-1]
+	if !strings.ContainsRune(floatFormatVerbs, rune(verb)) {
+		return 0, 0, false
+	}
+	digits := strings.TrimSuffix(spec, string(verb))
+	digits = strings.TrimPrefix(digits, ".")
+	prec, ok = parseFloatPrec(digits)
+	if !ok {
+		return 0, 0, false
+	}
+	return verb, prec, true
+}
+
+// parseFloatPrec parses the precision portion of a compact float format
+// spec. Empty or "-1" asks for the shortest round-tripping form, matching
+// big.Float.Text's prec == -1; anything else must be a non-negative
+// integer.
+func parseFloatPrec(digits string) (prec int, ok bool) {
+	switch digits {
+	case "", "-1":
+		return -1, true
+	}
+	n, err := strconv.Atoi(digits)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// formatBigFloat renders x according to format, a compact spec as accepted
+// by parseFloatFormat (e.g. "%.20g", "e30", "b"). If format isn't one of
+// these, formatBigFloat falls back to the traditional fmt verb in format,
+// applied to x.Float directly, so existing )format strings like "%v"
+// keep working unchanged. This is the rendering primitive BigFloat's own
+// scalar/matrix formatting should call with config.Format(); ValidFormat
+// below is what )format already uses today to reject a bad spec up front.
+func formatBigFloat(x BigFloat, format string) string {
+	if verb, prec, ok := parseFloatFormat(format); ok {
+		return x.Float.Text(verb, prec)
+	}
+	return fmt.Sprintf(format, x.Float)
+}
+
+// ValidFormat reports whether format is usable by formatBigFloat: either a
+// compact spec parseFloatFormat recognizes, or a traditional printf verb
+// that doesn't error out on a *big.Float, the same value formatBigFloat's
+// own fallback applies the spec to. )format uses this to reject a bad
+// spec at the moment it's set, rather than only when something is next
+// printed.
+func ValidFormat(format string) bool {
+	if _, _, ok := parseFloatFormat(format); ok {
+		return true
+	}
+	return !strings.Contains(fmt.Sprintf(format, big.NewFloat(0)), "%!")
+}
+
+// Sprint renders v for output, applying format (ordinarily
+// config.Format()) to every BigFloat value, including BigFloat elements
+// nested inside a Vector or Matrix, and falling back to the default
+// rendering for every other Value kind. This is the print path
+// formatBigFloat is meant to serve.
+func Sprint(v Value, format string) string {
+	switch v := v.(type) {
+	case BigFloat:
+		return formatBigFloat(v, format)
+	case Vector:
+		return sprintVector(v, format)
+	case Matrix:
+		return sprintMatrix(v, format)
+	}
+	return fmt.Sprint(v)
+}
+
+// sprintVector renders a vector by applying Sprint to each element and
+// joining the results the same way the default Vector formatting does.
+func sprintVector(v Vector, format string) string {
+	elems := make([]string, len(v))
+	for i, elem := range v {
+		elems[i] = Sprint(elem, format)
+	}
+	return strings.Join(elems, " ")
+}
+
+// sprintMatrix renders a matrix of any rank, applying Sprint (and so
+// formatBigFloat) to every element instead of falling back to fmt.Sprint
+// on the raw data vector. It recurses one dimension at a time, so a 2-D
+// matrix prints as rows and anything of higher rank prints as nested
+// planes of rows, each separated by a blank line, the same structure a
+// rank-3+ array's default renderer builds.
+func sprintMatrix(m Matrix, format string) string {
+	dims := make([]int, len(m.shape))
+	for i, s := range m.shape {
+		dims[i] = matrixDim(s)
+	}
+	return sprintDims(dims, m.data, format)
+}
+
+// sprintDims renders data, a row-major flattening of a dims-shaped array,
+// by peeling off the leading dimension and recursing on the rest.
+func sprintDims(dims []int, data Vector, format string) string {
+	switch len(dims) {
+	case 0:
+		if len(data) == 0 {
+			return ""
+		}
+		return Sprint(data[0], format)
+	case 1:
+		return sprintVector(data, format)
+	}
+
+	n, rest := dims[0], dims[1:]
+	size := 1
+	for _, d := range rest {
+		size *= d
+	}
+	sep := "\n"
+	if len(rest) > 1 {
+		sep = "\n\n"
+	}
+	planes := make([]string, n)
+	for i := 0; i < n; i++ {
+		planes[i] = sprintDims(rest, data[i*size:(i+1)*size], format)
+	}
+	return strings.Join(planes, sep)
+}
+
+// matrixDim extracts a small non-negative int from a shape element, which
+// is always an Int or BigInt in range even though Value is the general type.
+func matrixDim(v Value) int {
+	switch v := v.(type) {
+	case Int:
+		return int(v)
+	case BigInt:
+		return int(v.Int64())
+	}
+	return 0
+}
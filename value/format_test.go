@@ -0,0 +1,97 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestParseFloatFormat(t *testing.T) {
+	for _, test := range []struct {
+		spec     string
+		wantVerb byte
+		wantPrec int
+		wantOK   bool
+	}{
+		{"e30", 'e', 30, true},
+		{"g-1", 'g', -1, true},
+		{"b", 'b', -1, true},
+		{"%.20g", 'g', 20, true},
+		{".20g", 'g', 20, true},
+		{"%.20G", 'G', 20, true},
+		{"f0", 'f', 0, true},
+		{"", 0, 0, false},
+		{"%v", 0, 0, false},
+		{"q30", 0, 0, false},
+	} {
+		verb, prec, ok := parseFloatFormat(test.spec)
+		if ok != test.wantOK {
+			t.Errorf("parseFloatFormat(%q) ok = %v, want %v", test.spec, ok, test.wantOK)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if verb != test.wantVerb || prec != test.wantPrec {
+			t.Errorf("parseFloatFormat(%q) = (%q, %d), want (%q, %d)", test.spec, verb, prec, test.wantVerb, test.wantPrec)
+		}
+	}
+}
+
+func TestValidFormat(t *testing.T) {
+	for _, test := range []struct {
+		format string
+		want   bool
+	}{
+		{"e30", true},
+		{"%.20g", true},
+		{"%v", true},
+		{"%s", true},
+		{"%d", false},
+	} {
+		if got := ValidFormat(test.format); got != test.want {
+			t.Errorf("ValidFormat(%q) = %v, want %v", test.format, got, test.want)
+		}
+	}
+}
+
+func TestFormatBigFloat(t *testing.T) {
+	x := BigFloat{big.NewFloat(1.0 / 3.0).SetPrec(200)}
+	for _, format := range []string{"e10", "%.10e", "f5", "g-1"} {
+		if s := formatBigFloat(x, format); s == "" {
+			t.Errorf("formatBigFloat(1/3, %q) = %q, want non-empty", format, s)
+		}
+	}
+}
+
+func TestSprintRecursesIntoVectorAndMatrix(t *testing.T) {
+	third := BigFloat{big.NewFloat(1.0 / 3.0).SetPrec(200)}
+	vec := Vector{third, third}
+	got := Sprint(vec, "e5")
+	want := formatBigFloat(third, "e5") + " " + formatBigFloat(third, "e5")
+	if got != want {
+		t.Errorf("Sprint(vector, %q) = %q, want %q", "e5", got, want)
+	}
+
+	m := Matrix{shape: Vector{Int(2), Int(2)}, data: Vector{third, third, third, third}}
+	gotRows := Sprint(m, "e5")
+	wantRow := formatBigFloat(third, "e5") + " " + formatBigFloat(third, "e5")
+	wantRows := wantRow + "\n" + wantRow
+	if gotRows != wantRows {
+		t.Errorf("Sprint(matrix, %q) = %q, want %q", "e5", gotRows, wantRows)
+	}
+}
+
+func TestSprintMatrixHigherRank(t *testing.T) {
+	one := BigFloat{big.NewFloat(1).SetPrec(200)}
+	m := Matrix{shape: Vector{Int(2), Int(2), Int(2)}, data: Vector{one, one, one, one, one, one, one, one}}
+	got := sprintMatrix(m, "%v")
+	plane := "1 1\n1 1"
+	want := plane + "\n\n" + plane
+	if got != want {
+		t.Errorf("sprintMatrix(rank-3) = %q, want %q", got, want)
+	}
+}
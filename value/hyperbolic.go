@@ -0,0 +1,100 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import "math/big"
+
+func sinh(v Value) Value {
+	return evalFloatFunc(v, floatSinh)
+}
+
+func cosh(v Value) Value {
+	return evalFloatFunc(v, floatCosh)
+}
+
+func tanh(v Value) Value {
+	return evalFloatFunc(v, floatTanh)
+}
+
+func asinh(v Value) Value {
+	return evalFloatFunc(v, floatAsinh)
+}
+
+func acosh(v Value) Value {
+	return evalFloatFunc(v, floatAcosh)
+}
+
+func atanh(v Value) Value {
+	return evalFloatFunc(v, floatAtanh)
+}
+
+// floatSinh computes sinh(x) = (eˣ - e⁻ˣ)/2.
+func floatSinh(x *big.Float) *big.Float {
+	ex := floatExp(x)
+	enx := newF().Quo(floatOne, ex)
+	z := ex.Sub(ex, enx)
+	return z.Quo(z, floatTwo)
+}
+
+// floatCosh computes cosh(x) = (eˣ + e⁻ˣ)/2.
+func floatCosh(x *big.Float) *big.Float {
+	ex := floatExp(x)
+	enx := newF().Quo(floatOne, ex)
+	z := ex.Add(ex, enx)
+	return z.Quo(z, floatTwo)
+}
+
+// floatTanh computes tanh(x) = (e²ˣ - 1)/(e²ˣ + 1), which needs only one
+// call to exp instead of the two that the sinh/cosh definition would take.
+func floatTanh(x *big.Float) *big.Float {
+	twoX := newF().Mul(x, floatTwo)
+	e2x := floatExp(twoX)
+	num := newF().Sub(e2x, floatOne)
+	den := newF().Add(e2x, floatOne)
+	return num.Quo(num, den)
+}
+
+// floatAsinh computes asinh(x) = log(x + √(x²+1)), reusing the existing
+// sqrt and log primitives. Defined for all real x.
+func floatAsinh(x *big.Float) *big.Float {
+	// For negative x, x + √(x²+1) nearly cancels (√(x²+1) ≈ |x|), losing
+	// precision to the subtraction. asinh is odd, so compute the positive
+	// side, where the sum is well-conditioned, and negate.
+	if x.Sign() < 0 {
+		z := floatAsinh(newF().Neg(x))
+		return z.Neg(z)
+	}
+	z := newF().Mul(x, x)
+	z.Add(z, floatOne)
+	z = floatSqrt(z)
+	z.Add(z, x)
+	return floatLog(z)
+}
+
+// floatAcosh computes acosh(x) = log(x + √(x²-1)). Defined only for x ≥ 1.
+func floatAcosh(x *big.Float) *big.Float {
+	if x.Cmp(floatOne) < 0 {
+		Errorf("acosh of value less than one")
+	}
+	z := newF().Mul(x, x)
+	z.Sub(z, floatOne)
+	z = floatSqrt(z)
+	z.Add(z, x)
+	return floatLog(z)
+}
+
+// floatAtanh computes atanh(x) = ½·log((1+x)/(1-x)). Defined only for
+// |x| < 1.
+func floatAtanh(x *big.Float) *big.Float {
+	absX := newF().Abs(x)
+	if absX.Cmp(floatOne) >= 0 {
+		Errorf("atanh of value out of range (-1, 1)")
+	}
+	num := newF().Add(floatOne, x)
+	den := newF().Sub(floatOne, x)
+	z := num.Quo(num, den)
+	z = floatLog(z)
+	return z.Quo(z, floatTwo)
+}
@@ -0,0 +1,87 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestFloatHyperbolic(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		fn   func(*big.Float) *big.Float
+		want func(float64) float64
+		x    float64
+	}{
+		{"sinh(0)", floatSinh, math.Sinh, 0},
+		{"sinh(1)", floatSinh, math.Sinh, 1},
+		{"sinh(-2.5)", floatSinh, math.Sinh, -2.5},
+		{"cosh(0)", floatCosh, math.Cosh, 0},
+		{"cosh(1)", floatCosh, math.Cosh, 1},
+		{"cosh(-2.5)", floatCosh, math.Cosh, -2.5},
+		{"tanh(0)", floatTanh, math.Tanh, 0},
+		{"tanh(1)", floatTanh, math.Tanh, 1},
+		{"tanh(-20)", floatTanh, math.Tanh, -20},
+		{"asinh(0)", floatAsinh, math.Asinh, 0},
+		{"asinh(1)", floatAsinh, math.Asinh, 1},
+		{"asinh(-30)", floatAsinh, math.Asinh, -30},
+		{"acosh(1)", floatAcosh, math.Acosh, 1},
+		{"acosh(2)", floatAcosh, math.Acosh, 2},
+		{"acosh(30)", floatAcosh, math.Acosh, 30},
+		{"atanh(0)", floatAtanh, math.Atanh, 0},
+		{"atanh(0.5)", floatAtanh, math.Atanh, 0.5},
+		{"atanh(-0.9)", floatAtanh, math.Atanh, -0.9},
+	} {
+		x := big.NewFloat(test.x).SetPrec(200)
+		got, _ := test.fn(x).Float64()
+		want := test.want(test.x)
+		if !nearlyEqual(got, want) {
+			t.Errorf("%s = %v, want %v", test.name, got, want)
+		}
+	}
+}
+
+func TestFloatAsinhOddAroundZero(t *testing.T) {
+	// floatAsinh special-cases x < 0 to avoid cancellation; check it
+	// still agrees with the positive branch up to sign.
+	x := big.NewFloat(17.5).SetPrec(200)
+	pos, _ := floatAsinh(x).Float64()
+	neg, _ := floatAsinh(new(big.Float).SetPrec(200).Neg(x)).Float64()
+	if !nearlyEqual(pos, -neg) {
+		t.Errorf("floatAsinh(17.5) = %v, floatAsinh(-17.5) = %v, want negatives of each other", pos, neg)
+	}
+}
+
+func TestFloatAcoshDomain(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("floatAcosh(0.5) did not panic for x < 1")
+		}
+	}()
+	floatAcosh(big.NewFloat(0.5).SetPrec(200))
+}
+
+func TestFloatAtanhDomain(t *testing.T) {
+	for _, x := range []float64{1, -1, 2} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("floatAtanh(%v) did not panic for |x| >= 1", x)
+				}
+			}()
+			floatAtanh(big.NewFloat(x).SetPrec(200))
+		}()
+	}
+}
+
+func TestUnaryFnRegistersHyperbolics(t *testing.T) {
+	for _, name := range []string{"sinh", "cosh", "tanh", "asinh", "acosh", "atanh"} {
+		if unaryFn[name] == nil {
+			t.Errorf("unaryFn[%q] is not registered", name)
+		}
+	}
+}
@@ -4,7 +4,10 @@
 
 package value
 
-import "math/big"
+import (
+	"math"
+	"math/big"
+)
 
 func sqrt(v Value) Value {
 	return evalFloatFunc(v, floatSqrt)
@@ -14,8 +17,20 @@ func evalFloatFunc(v Value, fn func(*big.Float) *big.Float) Value {
 	return BigFloat{(fn(floatSelf(nil, v).(BigFloat).Float))}.shrink()
 }
 
-// floatSqrt computes the square root of x using Newton's method.
-// TODO: Use a better algorithm such as the one from math/sqrt.go.
+// floatSqrt computes the square root of x using Newton's method, following
+// the self-adjusting-precision scheme math/big's own Float.Sqrt uses: rather
+// than running the whole recurrence at the target precision from the first
+// iteration, start the working precision low and double it each step until
+// it reaches the requested precision plus a few guard bits. That way each
+// Newton step costs only as much as it needs to, instead of as much as the
+// final step does.
+//
+// The loop computes the reciprocal square root, z = 1/√x, via
+//
+//	z_{n+1} = z_n·(3 - x·z_n²)/2
+//
+// which needs no division, and a single closing multiplication by x turns
+// the result into √x.
 func floatSqrt(x *big.Float) *big.Float {
 	switch x.Sign() {
 	case -1:
@@ -24,33 +39,65 @@ func floatSqrt(x *big.Float) *big.Float {
 		return newF()
 	}
 
-	// Each iteration computes
-	// 	z = z - (z²-x)/2z
-	// delta holds the difference between the result
-	// this iteration and the previous. The loop stops
-	// when it hits zero.
-
-	// z holds the result so far. A good starting point is to halve the exponent.
-	// Experiments show we converge in only a handful of iterations.
-	z := newF()
-	exp := x.MantExp(z)
-	z.SetMantExp(z, exp/2)
-
-	// Intermediates, allocated once.
-	zSquared := newF()
-	num := newF()
-	den := newF()
-
-	loop := newLoop("sqrt", x, 1)
-	for {
+	// prec is the precision the answer is wanted at; wantPrec is that
+	// plus guard bits so the last couple of doublings don't cost us the
+	// low bit to rounding.
+	prec := x.Prec()
+	if prec == 0 {
+		prec = newF().Prec()
+	}
+	const guard = 32
+	wantPrec := prec + guard
+
+	// Initial guess for 1/√x: pull x's mantissa down to a float64 (x ==
+	// mant * 2**exp, mant in [0.5, 1)), use math.Sqrt to get a seed good
+	// to float64 precision, and halve and negate exp to place it. exp/2
+	// truncates in Go, so when exp is odd we fold the dropped half-bit
+	// into the mantissa first by doubling it, keeping the remaining
+	// exponent even and the division exact.
+	mant := new(big.Float).SetPrec(64).Set(x)
+	exp := mant.MantExp(mant)
+	mantF64, _ := mant.Float64()
+	if exp%2 != 0 {
+		mantF64 *= 2
+		exp--
+	}
+	z := new(big.Float).SetPrec(64).SetFloat64(1 / math.Sqrt(mantF64))
+	z.SetMantExp(z, -exp/2)
+
+	xAtPrec := new(big.Float)
+	zSquared := new(big.Float)
+	threeMinus := new(big.Float)
+	three := new(big.Float)
+
+	step := func(workPrec uint) {
+		z.SetPrec(workPrec)
+		xAtPrec.SetPrec(workPrec).Set(x)
+		zSquared.SetPrec(workPrec)
+		threeMinus.SetPrec(workPrec)
+		three.SetPrec(workPrec).SetInt64(3)
+
 		zSquared.Mul(z, z)
-		num.Sub(zSquared, x)
-		den.Mul(floatTwo, z)
-		num.Quo(num, den)
-		z.Sub(z, num)
-		if loop.terminate(z) {
-			break
-		}
+		threeMinus.Mul(xAtPrec, zSquared)
+		threeMinus.Sub(three, threeMinus)
+		z.Mul(z, threeMinus)
+		z.Quo(z, floatTwo)
+	}
+
+	for workPrec := uint(64); workPrec < wantPrec; workPrec *= 2 {
+		step(workPrec)
 	}
-	return z
+	// One final step at the full working precision...
+	step(wantPrec)
+	// ...and one more as a polish; at this precision it should leave z
+	// unchanged, but it costs little and guards against an unlucky round
+	// in the step above.
+	step(wantPrec)
+
+	// z now holds 1/√x at wantPrec; multiply by x to recover √x, rounding
+	// the product directly to the precision the caller actually wants.
+	// Rounding z itself to prec first and then multiplying would round
+	// twice, occasionally landing a ULP away from the correctly-rounded
+	// result.
+	return new(big.Float).SetPrec(prec).Mul(z, x)
 }
@@ -0,0 +1,76 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestFloatSqrt(t *testing.T) {
+	for _, test := range []struct {
+		x float64
+	}{
+		{0},
+		{1},
+		{2},
+		{4},
+		{0.25},
+		{1e-30},
+		{1e30},
+		{123456789.987654321},
+	} {
+		x := big.NewFloat(test.x).SetPrec(200)
+		got := floatSqrt(x)
+		want := math.Sqrt(test.x)
+		gotF64, _ := got.Float64()
+		if !nearlyEqual(gotF64, want) {
+			t.Errorf("floatSqrt(%v) = %v, want %v", test.x, gotF64, want)
+		}
+	}
+}
+
+func TestFloatSqrtNegative(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("floatSqrt(-1) did not panic")
+		}
+	}()
+	floatSqrt(big.NewFloat(-1).SetPrec(200))
+}
+
+func TestFloatSqrtHighPrecision(t *testing.T) {
+	// 2 has no exact binary square root, but at high precision the Newton
+	// iteration should still recover x to well within the precision's
+	// relative error budget when squared back.
+	x := big.NewFloat(2).SetPrec(500)
+	z := floatSqrt(x)
+	zSquared := new(big.Float).SetPrec(500).Mul(z, z)
+	relErr := new(big.Float).SetPrec(500).Sub(zSquared, x)
+	relErr.Quo(relErr, x)
+	relErr.Abs(relErr)
+	tolerance := new(big.Float).SetPrec(500).SetFloat64(1e-140)
+	if relErr.Cmp(tolerance) > 0 {
+		t.Errorf("floatSqrt(2) not accurate at prec 500: relative error %v, want <= %v", relErr, tolerance)
+	}
+}
+
+func TestUnaryFnRegistersSqrt(t *testing.T) {
+	if unaryFn["sqrt"] == nil {
+		t.Error(`unaryFn["sqrt"] is not registered`)
+	}
+}
+
+// nearlyEqual reports whether a and b agree to within a small relative
+// tolerance, loose enough to absorb the float64 round trip used to check
+// floatSqrt's *big.Float result against math.Sqrt.
+func nearlyEqual(a, b float64) bool {
+	if a == b {
+		return true
+	}
+	diff := math.Abs(a - b)
+	return diff <= 1e-12*math.Max(math.Abs(a), math.Abs(b))
+}
@@ -0,0 +1,22 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+// unaryFn maps the name ivy's parser recognizes for a built-in unary
+// operator to the Go function that implements it. Without an entry here,
+// a function such as sinh is reachable only from other Go code, never
+// from ivy syntax.
+var unaryFn = map[string]func(Value) Value{
+	"sqrt":  sqrt,
+	"asin":  asin,
+	"acos":  acos,
+	"atan":  atan,
+	"sinh":  sinh,
+	"cosh":  cosh,
+	"tanh":  tanh,
+	"asinh": asinh,
+	"acosh": acosh,
+	"atanh": atanh,
+}